@@ -209,6 +209,8 @@ require (
 	go.opentelemetry.io/collector/config/configtelemetry v0.116.0
 	go.opentelemetry.io/collector/config/configtls v1.22.0
 	go.opentelemetry.io/collector/confmap v1.22.0
+	go.opentelemetry.io/collector/confmap/provider/httpprovider v1.22.0
+	go.opentelemetry.io/collector/confmap/provider/httpsprovider v1.22.0
 	go.opentelemetry.io/collector/confmap/provider/yamlprovider v1.22.0
 	go.opentelemetry.io/collector/connector v0.116.0
 	go.opentelemetry.io/collector/connector/connectortest v0.116.0
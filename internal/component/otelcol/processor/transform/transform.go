@@ -59,6 +59,7 @@ type ContextStatementsSlice []ContextStatements
 
 type ContextStatements struct {
 	Context    ContextID `alloy:"context,attr"`
+	Conditions []string  `alloy:"conditions,attr,optional"`
 	Statements []string  `alloy:"statements,attr"`
 }
 
@@ -123,10 +124,14 @@ func (args *ContextStatements) convert() map[string]interface{} {
 		return nil
 	}
 
-	return map[string]interface{}{
+	res := map[string]interface{}{
 		"context":    args.Context,
 		"statements": args.Statements,
 	}
+	if len(args.Conditions) > 0 {
+		res["conditions"] = args.Conditions
+	}
+	return res
 }
 
 // Convert implements processor.Arguments.
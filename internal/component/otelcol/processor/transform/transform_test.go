@@ -415,6 +415,31 @@ func TestArguments_UnmarshalAlloy(t *testing.T) {
 				},
 			},
 		},
+		{
+			testName: "ConditionsWithoutStatements",
+			cfg: `
+			trace_statements {
+				context = "span"
+				conditions = [
+					` + backtick + `attributes["http.path"] == "/health"` + backtick + `,
+				]
+				statements = []
+			}
+			output {}
+			`,
+			expected: map[string]interface{}{
+				"error_mode": "propagate",
+				"trace_statements": []interface{}{
+					map[string]interface{}{
+						"context": "span",
+						"conditions": []interface{}{
+							`attributes["http.path"] == "/health"`,
+						},
+						"statements": []interface{}{},
+					},
+				},
+			},
+		},
 		{
 			testName: "unknown_error_mode",
 			cfg: `
@@ -67,6 +67,12 @@ type State struct {
 	componentID          componentstatus.InstanceID // ID of the current component being converted.
 	componentConfig      component.Config           // Config of the current component being converted.
 	componentLabelPrefix string                     // Prefix for the label of the current component being converted.
+	componentSignal      pipeline.Signal            // Signal handled by the current component, or the zero value for all signals.
+
+	// expandProcessor reports whether the processor with the given ID is
+	// converted once per pipeline rather than once per pipeline group. A nil
+	// expandProcessor never expands processors.
+	expandProcessor func(id component.ID) bool
 }
 
 type converterKey struct {
@@ -82,12 +88,13 @@ func (state *State) Body() *builder.Body { return state.file.Body() }
 // Component component being converted. It is safe to use this label to create
 // multiple Alloy components in a chain.
 func (state *State) AlloyComponentLabel() string {
-	return state.alloyLabelForComponent(state.componentID)
+	return state.alloyLabelForComponent(state.componentID, state.componentSignal)
 }
 
 // alloyLabelForComponent returns the unique Alloy label for the given
-// OpenTelemetry Collector component.
-func (state *State) alloyLabelForComponent(c componentstatus.InstanceID) string {
+// OpenTelemetry Collector component. The signal is only used to label
+// processors when components are being expanded.
+func (state *State) alloyLabelForComponent(c componentstatus.InstanceID, signal pipeline.Signal) string {
 	const defaultLabel = "default"
 
 	// We need to prove that it's possible to statelessly compute the label for a
@@ -141,12 +148,24 @@ func (state *State) alloyLabelForComponent(c componentstatus.InstanceID) string
 		unsanitizedLabel += fmt.Sprintf("%s_%s", groupName, componentName)
 	}
 
+	// Expanded processors are created once per pipeline, so the signal of the
+	// pipeline is needed to keep their labels unique.
+	if c.Kind() == component.KindProcessor && signal != (pipeline.Signal{}) && state.expandProcessor != nil && state.expandProcessor(c.ComponentID()) {
+		unsanitizedLabel += "_" + signal.String()
+	}
+
 	return common.SanitizeIdentifierPanics(unsanitizedLabel)
 }
 
 // Next returns the set of Alloy component IDs for a given data type that the
 // current component being converted should forward data to.
 func (state *State) Next(c componentstatus.InstanceID, signal pipeline.Signal) []componentID {
+	// A component which was converted for a single signal must not forward
+	// any other signal.
+	if state.componentSignal != (pipeline.Signal{}) && state.componentSignal != signal {
+		return nil
+	}
+
 	instances := state.nextInstances(c, signal)
 
 	var ids []componentID
@@ -168,7 +187,7 @@ func (state *State) Next(c componentstatus.InstanceID, signal pipeline.Signal) [
 			panic(fmt.Sprintf("otelcolconvert: converter %T returned empty component name", converter))
 		}
 
-		componentLabel := state.alloyLabelForComponent(instance)
+		componentLabel := state.alloyLabelForComponent(instance, signal)
 
 		ids = append(ids, componentID{
 			Name:  strings.Split(componentName, "."),
@@ -204,6 +223,21 @@ func (state *State) LookupExtension(id component.ID) componentID {
 	return cid
 }
 
+// checkExtension returns a critical diagnostic if the extension with the given
+// ID, which is referenced by the component being converted, isn't enabled in
+// the service. Disabled extensions aren't converted, so there would be no
+// Alloy component to reference.
+func (state *State) checkExtension(id component.ID) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if _, ok := state.extensionLookup[id]; !ok {
+		diags.Add(diag.SeverityLevelCritical, fmt.Sprintf(
+			"%s references the extension %q, which is not enabled in service::extensions",
+			StringifyInstanceID(state.componentID), id.String(),
+		))
+	}
+	return diags
+}
+
 type componentID struct {
 	Name  []string
 	Label string
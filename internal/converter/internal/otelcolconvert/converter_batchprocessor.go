@@ -17,6 +17,9 @@ func init() {
 	converters = append(converters, batchProcessorConverter{})
 }
 
+// batchProcessorType is the component type of the batch processor.
+var batchProcessorType = component.MustNewType("batch")
+
 type batchProcessorConverter struct{}
 
 func (batchProcessorConverter) Factory() component.Factory {
@@ -2,6 +2,7 @@ package otelcolconvert
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/grafana/alloy/internal/component/otelcol"
 	"github.com/grafana/alloy/internal/component/otelcol/receiver/kafka"
@@ -30,6 +31,8 @@ func (kafkaReceiverConverter) InputComponentName() string { return "" }
 func (kafkaReceiverConverter) ConvertAndAppend(state *State, id componentstatus.InstanceID, cfg component.Config) diag.Diagnostics {
 	var diags diag.Diagnostics
 
+	diags.AddAll(validateKafkaReceiverEncoding(state, id, cfg.(*kafkareceiver.Config).Encoding))
+
 	label := state.AlloyComponentLabel()
 
 	args := toKafkaReceiver(state, id, cfg.(*kafkareceiver.Config))
@@ -44,6 +47,57 @@ func (kafkaReceiverConverter) ConvertAndAppend(state *State, id componentstatus.
 	return diags
 }
 
+// kafkaReceiverEncodings holds the built-in encodings of the kafka receiver
+// for every signal. Any other encoding refers to an encoding extension.
+var kafkaReceiverEncodings = []struct {
+	signal    pipeline.Signal
+	encodings []string
+}{
+	{pipeline.SignalMetrics, []string{"otlp_proto", "otlp_json"}},
+	{pipeline.SignalLogs, []string{"otlp_proto", "otlp_json", "raw", "text", "json", "azure_resource_logs"}},
+	{pipeline.SignalTraces, []string{"otlp_proto", "otlp_json", "jaeger_proto", "jaeger_json", "zipkin_proto", "zipkin_json", "zipkin_thrift"}},
+}
+
+// validateKafkaReceiverEncoding warns if encoding can't be used for every
+// signal the receiver forwards, since otelcol.receiver.kafka would fail to
+// start.
+func validateKafkaReceiverEncoding(state *State, id componentstatus.InstanceID, encoding string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	supports := func(encodings []string) bool {
+		for _, e := range encodings {
+			// Text encodings may name a character set, such as "text_utf-8".
+			if encoding == e || (e == "text" && strings.HasPrefix(encoding, "text_")) {
+				return true
+			}
+		}
+		return false
+	}
+
+	builtin := false
+	for _, se := range kafkaReceiverEncodings {
+		builtin = builtin || supports(se.encodings)
+	}
+	if !builtin {
+		diags.Add(
+			diag.SeverityLevelWarn,
+			fmt.Sprintf("%s: encoding %q isn't a built-in encoding, and otelcol.receiver.kafka doesn't support encoding extensions", StringifyInstanceID(id), encoding),
+		)
+		return diags
+	}
+
+	for _, se := range kafkaReceiverEncodings {
+		if len(state.Next(id, se.signal)) > 0 && !supports(se.encodings) {
+			diags.Add(
+				diag.SeverityLevelWarn,
+				fmt.Sprintf("%s: encoding %q can't be used to receive %s, so otelcol.receiver.kafka will fail to start", StringifyInstanceID(id), encoding, se.signal),
+			)
+		}
+	}
+
+	return diags
+}
+
 func toKafkaReceiver(state *State, id componentstatus.InstanceID, cfg *kafkareceiver.Config) *kafka.Arguments {
 	var (
 		nextMetrics = state.Next(id, pipeline.SignalMetrics)
@@ -32,6 +32,12 @@ func (loadbalancingExporterConverter) InputComponentName() string {
 func (loadbalancingExporterConverter) ConvertAndAppend(state *State, id componentstatus.InstanceID, cfg component.Config) diag.Diagnostics {
 	var diags diag.Diagnostics
 
+	if a := cfg.(*loadbalancingexporter.Config).Protocol.OTLP.Auth; a != nil {
+		if extDiags := state.checkExtension(a.AuthenticatorID); len(extDiags) > 0 {
+			return extDiags
+		}
+	}
+
 	label := state.AlloyComponentLabel()
 	overrideHook := func(val interface{}) interface{} {
 		switch val.(type) {
@@ -35,6 +35,15 @@ func (otlpExporterConverter) InputComponentName() string { return "otelcol.expor
 func (otlpExporterConverter) ConvertAndAppend(state *State, id componentstatus.InstanceID, cfg component.Config) diag.Diagnostics {
 	var diags diag.Diagnostics
 
+	if a := cfg.(*otlpexporter.Config).Auth; a != nil {
+		if extDiags := state.checkExtension(a.AuthenticatorID); len(extDiags) > 0 {
+			return extDiags
+		}
+	}
+
+	clientCfg := cfg.(*otlpexporter.Config).ClientConfig
+	diags.AddAll(validateTLSEndpoint(id, clientCfg.Endpoint, clientCfg.TLSSetting))
+
 	label := state.AlloyComponentLabel()
 	overrideHook := func(val interface{}) interface{} {
 		switch val.(type) {
@@ -130,6 +139,20 @@ func toTLSClientArguments(cfg configtls.ClientConfig) otelcol.TLSClientArguments
 	}
 }
 
+// validateTLSEndpoint warns when an https:// endpoint is combined with
+// tls.insecure. The scheme takes precedence, so the connection still uses TLS
+// and the insecure setting is silently ignored.
+func validateTLSEndpoint(id componentstatus.InstanceID, endpoint string, cfg configtls.ClientConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if cfg.Insecure && strings.HasPrefix(strings.ToLower(endpoint), "https://") {
+		diags.Add(
+			diag.SeverityLevelWarn,
+			fmt.Sprintf("%s: endpoint %q uses https:// but tls.insecure is set; the connection will use TLS. Remove tls.insecure or use an http:// endpoint", StringifyInstanceID(id), endpoint),
+		)
+	}
+	return diags
+}
+
 func toKeepaliveClientArguments(cfg *configgrpc.KeepaliveClientConfig) *otelcol.KeepaliveClientArguments {
 	if cfg == nil {
 		return nil
@@ -2,6 +2,7 @@ package otelcolconvert
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
@@ -34,6 +35,16 @@ func (otlpHTTPExporterConverter) InputComponentName() string {
 func (otlpHTTPExporterConverter) ConvertAndAppend(state *State, id componentstatus.InstanceID, cfg component.Config) diag.Diagnostics {
 	var diags diag.Diagnostics
 
+	if a := cfg.(*otlphttpexporter.Config).Auth; a != nil {
+		if extDiags := state.checkExtension(a.AuthenticatorID); len(extDiags) > 0 {
+			return extDiags
+		}
+	}
+
+	diags.AddAll(validateHTTPEndpoints(id, cfg.(*otlphttpexporter.Config)))
+	clientCfg := cfg.(*otlphttpexporter.Config).ClientConfig
+	diags.AddAll(validateTLSEndpoint(id, clientCfg.Endpoint, clientCfg.TLSSetting))
+
 	label := state.AlloyComponentLabel()
 	overrideHook := func(val interface{}) interface{} {
 		switch val.(type) {
@@ -63,9 +74,43 @@ func toOtelcolExporterOTLPHTTP(cfg *otlphttpexporter.Config) *otlphttp.Arguments
 		Retry:        toRetryArguments(cfg.RetryConfig),
 		Encoding:     string(cfg.Encoding),
 		DebugMetrics: common.DefaultValue[otlphttp.Arguments]().DebugMetrics,
+
+		TracesEndpoint:  cfg.TracesEndpoint,
+		MetricsEndpoint: cfg.MetricsEndpoint,
+		LogsEndpoint:    cfg.LogsEndpoint,
 	}
 }
 
+// validateHTTPEndpoints warns about endpoints without an http or https scheme.
+// Unlike the gRPC client, which accepts a bare host:port, the HTTP client
+// doesn't default a scheme, so every request to such an endpoint fails in both
+// the collector and Alloy. The scheme isn't guessed since it depends on
+// whether the server uses TLS.
+func validateHTTPEndpoints(id componentstatus.InstanceID, cfg *otlphttpexporter.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	endpoints := []struct{ name, value string }{
+		{"endpoint", cfg.Endpoint},
+		{"traces_endpoint", cfg.TracesEndpoint},
+		{"metrics_endpoint", cfg.MetricsEndpoint},
+		{"logs_endpoint", cfg.LogsEndpoint},
+	}
+	for _, e := range endpoints {
+		if e.value == "" {
+			continue
+		}
+		if u, err := url.Parse(e.value); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+			continue
+		}
+		diags.Add(
+			diag.SeverityLevelWarn,
+			fmt.Sprintf("%s: %s %q must start with http:// or https:// for otelcol.exporter.otlphttp to send data", StringifyInstanceID(id), e.name, e.value),
+		)
+	}
+
+	return diags
+}
+
 func toHTTPClientArguments(cfg confighttp.ClientConfig) otelcol.HTTPClientArguments {
 	var a *auth.Handler
 	if cfg.Auth != nil {
@@ -100,5 +145,17 @@ func toHTTPClientArguments(cfg confighttp.ClientConfig) otelcol.HTTPClientArgume
 		HTTP2ReadIdleTimeout: cfg.HTTP2ReadIdleTimeout,
 
 		Authentication: a,
+
+		Cookies: toCookies(cfg.Cookies),
+	}
+}
+
+func toCookies(cfg *confighttp.CookiesConfig) *otelcol.Cookies {
+	if cfg == nil {
+		return nil
+	}
+
+	return &otelcol.Cookies{
+		Enabled: cfg.Enabled,
 	}
 }
@@ -29,7 +29,15 @@ func (solaceReceiverConverter) ConvertAndAppend(state *State, id componentstatus
 
 	label := state.AlloyComponentLabel()
 
-	args := toSolaceReceiver(state, id, cfg.(*solacereceiver.Config))
+	solaceCfg := cfg.(*solacereceiver.Config)
+	if len(solaceCfg.Broker) > 1 {
+		diags.Add(
+			diag.SeverityLevelWarn,
+			fmt.Sprintf("%s: otelcol.receiver.solace only supports a single broker, only %q will be used", StringifyInstanceID(id), solaceCfg.Broker[0]),
+		)
+	}
+
+	args := toSolaceReceiver(state, id, solaceCfg)
 	block := common.NewBlockWithOverride([]string{"otelcol", "receiver", "solace"}, label, args)
 
 	diags.Add(
@@ -68,9 +68,22 @@ func toTransformProcessor(state *State, id componentstatus.InstanceID, cfg *tran
 func toContextStatements(in []map[string]any) []transform.ContextStatements {
 	res := make([]transform.ContextStatements, 0, len(in))
 	for _, s := range in {
+		// A group may only have conditions set. Make sure an empty list of
+		// statements is still rendered, since the attribute is required.
+		statements, _ := s["statements"].([]string)
+		if statements == nil {
+			statements = []string{}
+		}
+
+		var conditions []string
+		if c, _ := s["conditions"].([]string); len(c) > 0 {
+			conditions = c
+		}
+
 		res = append(res, transform.ContextStatements{
 			Context:    transform.ContextID(encodeString(s["context"])),
-			Statements: s["statements"].([]string),
+			Conditions: conditions,
+			Statements: statements,
 		})
 	}
 
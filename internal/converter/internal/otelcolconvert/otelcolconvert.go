@@ -3,7 +3,10 @@ package otelcolconvert
 import (
 	"bytes"
 	"context"
+	"flag"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
 
 	"github.com/grafana/alloy/internal/converter/diag"
@@ -12,14 +15,18 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/httpprovider"
+	"go.opentelemetry.io/collector/confmap/provider/httpsprovider"
 	"go.opentelemetry.io/collector/confmap/provider/yamlprovider"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
-	"golang.org/x/exp/maps"
+	"go.opentelemetry.io/collector/service/pipelines"
+	"golang.org/x/exp/slices"
 )
 
 // This package is split into a set of [componentConverter] implementations
@@ -42,59 +49,224 @@ import (
 
 // Convert implements an Opentelemetry Collector config converter.
 //
-// For compatibility with other converters, the extraArgs paramater is defined
-// but unused, and a critical error diagnostic is returned if extraArgs is
-// non-empty.
+// extraArgs may be used to pass the following flags to the converter:
+//
+//   - --label-prefix=<prefix>: prefix the labels of all generated components.
+//   - --pretty=false: skip pretty-printing the generated config.
+//   - --allow-http-includes: resolve ${http://...} and ${https://...}
+//     references in the input by fetching them.
+//
+// A critical error diagnostic is returned if extraArgs contains any other
+// argument.
 func Convert(in []byte, extraArgs []string) ([]byte, diag.Diagnostics) {
+	out, _, diags := ConvertWithOptions([][]byte{in}, extraArgs, Options{})
+	return out, diags
+}
+
+// Options holds optional settings for converting an OpenTelemetry Collector
+// config.
+type Options struct {
+	// ExpandComponents disables sharing a single Alloy component across the
+	// pipelines of a pipeline group where the OpenTelemetry Collector would
+	// create one instance per pipeline.
+	//
+	// When set, processors are emitted once for every pipeline they're used in,
+	// with the telemetry signal appended to their label. Receivers and
+	// exporters are still emitted once per pipeline group, since the Collector
+	// shares a single instance of those across pipelines.
+	ExpandComponents bool
+
+	// ShareBatchProcessors keeps batch processors shared across the pipelines
+	// of a pipeline group when ExpandComponents is set, so that each batch
+	// processor ID is converted into a single Alloy component with per-signal
+	// outputs.
+	//
+	// It has no effect unless ExpandComponents is set, since processors are
+	// always shared otherwise. Sharing is done per processor ID, and every use
+	// of an ID refers to the same config, so there's no need to check that
+	// the config is identical across the pipelines.
+	ShareBatchProcessors bool
+
+	// LabelPrefix is prepended to the label of every generated component.
+	LabelPrefix string
+
+	// DisablePrettyPrint returns the generated config as rendered, without
+	// pretty-printing it.
+	DisablePrettyPrint bool
+
+	// AllowHTTPIncludes resolves ${http://...} and ${https://...} references
+	// in the input config by fetching them. When unset, such references fail
+	// the conversion, so that converting a config never makes network requests
+	// unless asked to.
+	AllowHTTPIncludes bool
+
+	// ExtraConverters are used to convert components in addition to the
+	// built-in converters. This allows converting configs which use components
+	// that aren't supported by the otelcol converter out of the box.
+	//
+	// ExtraConverters take precedence over the built-in converters: if an extra
+	// converter handles the same component kind and type as a built-in one, the
+	// extra converter and its factory are used instead. If two extra converters
+	// handle the same component, the first one wins.
+	ExtraConverters []ComponentConverter
+}
+
+// expandsProcessor reports whether the processor with the given ID is
+// converted once per pipeline.
+func (opts Options) expandsProcessor(id component.ID) bool {
+	if !opts.ExpandComponents {
+		return false
+	}
+	return !opts.ShareBatchProcessors || id.Type() != batchProcessorType
+}
+
+// parseExtraArgs applies the flags passed through extraArgs to opts.
+func parseExtraArgs(extraArgs []string, opts *Options) error {
+	fs := flag.NewFlagSet("otelcol", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	pretty := !opts.DisablePrettyPrint
+	fs.StringVar(&opts.LabelPrefix, "label-prefix", opts.LabelPrefix, "Prefix for the labels of generated components.")
+	fs.BoolVar(&pretty, "pretty", pretty, "Pretty-print the generated config.")
+	fs.BoolVar(&opts.AllowHTTPIncludes, "allow-http-includes", opts.AllowHTTPIncludes, "Fetch config fragments referenced through http and https URIs.")
+
+	if err := fs.Parse(joinBoolFlagValues(fs, extraArgs)); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %s", fs.Args())
+	}
+
+	opts.DisablePrettyPrint = !pretty
+	return nil
+}
+
+// joinBoolFlagValues joins boolean flags in args with a value passed as a
+// separate argument, such as ["--pretty", "false"]. The convert command splits
+// "--pretty=false" into that form, but the flag package only accepts values
+// of boolean flags after an equals sign.
+func joinBoolFlagValues(fs *flag.FlagSet, args []string) []string {
+	res := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		var isBool bool
+		if name := strings.TrimLeft(arg, "-"); name != arg {
+			if f := fs.Lookup(name); f != nil {
+				bf, ok := f.Value.(interface{ IsBoolFlag() bool })
+				isBool = ok && bf.IsBoolFlag()
+			}
+		}
+		if isBool && i+1 < len(args) {
+			if _, err := strconv.ParseBool(args[i+1]); err == nil {
+				arg += "=" + args[i+1]
+				i++
+			}
+		}
+		res = append(res, arg)
+	}
+	return res
+}
+
+// ConvertWithOptions is like [Convert], but converts a set of input config
+// files and allows customizing the conversion through opts. Flags passed
+// through extraArgs override the matching fields of opts.
+//
+// The inputs are merged in order using the same semantics as the OpenTelemetry
+// Collector uses when it is given multiple --config flags: maps are merged
+// recursively, while scalar values and lists in later inputs replace the ones
+// from earlier inputs. The merged config is then converted as a single config.
+//
+// It also returns a [Report] describing which components were converted into
+// which Alloy components. The report is nil if the inputs could not be
+// converted.
+func ConvertWithOptions(inputs [][]byte, extraArgs []string, opts Options) ([]byte, *Report, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
-	if len(extraArgs) > 0 {
-		diags.Add(diag.SeverityLevelCritical, fmt.Sprintf("extra arguments are not supported for the otelcol converter: %s", extraArgs))
-		return nil, diags
+	if err := parseExtraArgs(extraArgs, &opts); err != nil {
+		diags.Add(diag.SeverityLevelCritical, fmt.Sprintf("extra arguments are not supported for the otelcol converter: %s", err))
+		return nil, nil, diags
 	}
+	if len(inputs) == 0 {
+		diags.Add(diag.SeverityLevelCritical, "no input configs were provided to the otelcol converter")
+		return nil, nil, diags
+	}
+
+	// Factories are built once and shared between reading the config and
+	// looking up converters for the components in it.
+	entries := newConverterEntries(opts.ExtraConverters)
 
-	cfg, err := readOpentelemetryConfig(in)
+	cfg, err := readOpentelemetryConfig(getFactories(entries), opts, inputs...)
 	if err != nil {
 		diags.Add(diag.SeverityLevelCritical, err.Error())
-		return nil, diags
+		return nil, nil, diags
+	}
+	// Incomplete pipelines are reported before validating the config, since
+	// validation only reports the first of them.
+	if pipelineDiags := validatePipelines(cfg); len(pipelineDiags) > 0 {
+		return nil, nil, pipelineDiags
 	}
 	if err := cfg.Validate(); err != nil {
-		diags.Add(diag.SeverityLevelCritical, fmt.Sprintf("failed to validate config: %s", err))
-		return nil, diags
+		if len(inputs) > 1 {
+			diags.Add(diag.SeverityLevelCritical, fmt.Sprintf("failed to validate merged config from %d inputs: %s", len(inputs), err))
+		} else {
+			diags.Add(diag.SeverityLevelCritical, fmt.Sprintf("failed to validate config: %s", err))
+		}
+		return nil, nil, diags
 	}
 
 	f := builder.NewFile()
+	report := &Report{}
 
-	diags.AddAll(AppendConfig(f, cfg, "", nil))
+	diags.AddAll(appendConfig(f, cfg, buildConverterTable(entries), opts, report))
 	diags.AddAll(common.ValidateNodes(f))
 
 	var buf bytes.Buffer
 	if _, err := f.WriteTo(&buf); err != nil {
 		diags.Add(diag.SeverityLevelCritical, fmt.Sprintf("failed to render Alloy config: %s", err.Error()))
-		return nil, diags
+		return nil, nil, diags
 	}
 
 	if len(buf.Bytes()) == 0 {
-		return nil, diags
+		return nil, report, diags
+	}
+	if opts.DisablePrettyPrint {
+		return buf.Bytes(), report, diags
 	}
 
 	prettyByte, newDiags := common.PrettyPrint(buf.Bytes())
 	diags.AddAll(newDiags)
-	return prettyByte, diags
+	return prettyByte, report, diags
 }
 
-func readOpentelemetryConfig(in []byte) (*otelcol.Config, error) {
+// readOpentelemetryConfig reads the provided inputs as an OpenTelemetry
+// Collector config. Each input is registered as a separate URI so that confmap
+// merges them in order, just like the Collector does.
+//
+// Only the yaml provider is registered, unless opts.AllowHTTPIncludes is set,
+// in which case http and https references are also resolved.
+func readOpentelemetryConfig(factories otelcol.Factories, opts Options, inputs ...[]byte) (*otelcol.Config, error) {
+	uris := make([]string, 0, len(inputs))
+	for _, in := range inputs {
+		uris = append(uris, "yaml:"+string(in))
+	}
+
+	providers := []confmap.ProviderFactory{yamlprovider.NewFactory()}
+	if opts.AllowHTTPIncludes {
+		providers = append(providers, httpprovider.NewFactory(), httpsprovider.NewFactory())
+	}
+
 	configProvider, err := otelcol.NewConfigProvider(otelcol.ConfigProviderSettings{
 		ResolverSettings: confmap.ResolverSettings{
-			URIs:              []string{"yaml:" + string(in)},
-			ProviderFactories: []confmap.ProviderFactory{yamlprovider.NewFactory()},
+			URIs:              uris,
+			ProviderFactories: providers,
 		},
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create otelcol config provider: %w", err)
 	}
 
-	cfg, err := configProvider.Get(context.Background(), getFactories())
+	cfg, err := configProvider.Get(context.Background(), factories)
 	if err != nil {
 		// TODO(rfratto): users may pass unknown components in YAML here. Can we
 		// improve the errors? Can we ignore the errors?
@@ -104,7 +276,35 @@ func readOpentelemetryConfig(in []byte) (*otelcol.Config, error) {
 	return cfg, nil
 }
 
-func getFactories() otelcol.Factories {
+// converterEntry pairs a converter with the factory it returned. Building
+// factories can be expensive, so entries are created once per conversion and
+// reused everywhere a factory is needed.
+type converterEntry struct {
+	Converter ComponentConverter
+	Factory   component.Factory
+}
+
+// newConverterEntries calls Factory exactly once for each of the
+// extraConverters followed by the built-in converters, preserving that order.
+func newConverterEntries(extraConverters []ComponentConverter) []converterEntry {
+	entries := make([]converterEntry, 0, len(extraConverters)+len(converters))
+
+	for _, convs := range [][]ComponentConverter{extraConverters, converters} {
+		for _, conv := range convs {
+			entries = append(entries, converterEntry{
+				Converter: conv,
+				Factory:   conv.Factory(),
+			})
+		}
+	}
+
+	return entries
+}
+
+// getFactories returns the factories of entries. If multiple entries return a
+// factory for the same component kind and type, the first one wins, matching
+// [buildConverterTable].
+func getFactories(entries []converterEntry) otelcol.Factories {
 	facts := otelcol.Factories{
 		Receivers:  make(map[component.Type]receiver.Factory),
 		Processors: make(map[component.Type]processor.Factory),
@@ -113,20 +313,20 @@ func getFactories() otelcol.Factories {
 		Connectors: make(map[component.Type]connector.Factory),
 	}
 
-	for _, converter := range converters {
-		fact := converter.Factory()
+	for _, entry := range entries {
+		fact := entry.Factory
 
 		switch fact := fact.(type) {
 		case receiver.Factory:
-			facts.Receivers[fact.Type()] = fact
+			addFactory(facts.Receivers, fact)
 		case processor.Factory:
-			facts.Processors[fact.Type()] = fact
+			addFactory(facts.Processors, fact)
 		case exporter.Factory:
-			facts.Exporters[fact.Type()] = fact
+			addFactory(facts.Exporters, fact)
 		case extension.Factory:
-			facts.Extensions[fact.Type()] = fact
+			addFactory(facts.Extensions, fact)
 		case connector.Factory:
-			facts.Connectors[fact.Type()] = fact
+			addFactory(facts.Connectors, fact)
 
 		default:
 			panic(fmt.Sprintf("unknown component factory type %T", fact))
@@ -136,9 +336,30 @@ func getFactories() otelcol.Factories {
 	return facts
 }
 
+// addFactory adds fact to facts unless a factory for its type already exists.
+func addFactory[T component.Factory](facts map[component.Type]T, fact T) {
+	if _, ok := facts[fact.Type()]; !ok {
+		facts[fact.Type()] = fact
+	}
+}
+
 // AppendConfig converts the provided OpenTelemetry config into an equivalent
 // Alloy config and appends the result to the provided file.
 func AppendConfig(file *builder.File, cfg *otelcol.Config, labelPrefix string, extraConverters []ComponentConverter) diag.Diagnostics {
+	return AppendConfigWithOptions(file, cfg, Options{LabelPrefix: labelPrefix, ExtraConverters: extraConverters})
+}
+
+// AppendConfigWithOptions is like [AppendConfig], but allows customizing the
+// output through opts. Options which only affect reading or rendering the
+// config, such as opts.AllowHTTPIncludes and opts.DisablePrettyPrint, are
+// ignored.
+func AppendConfigWithOptions(file *builder.File, cfg *otelcol.Config, opts Options) diag.Diagnostics {
+	return appendConfig(file, cfg, buildConverterTable(newConverterEntries(opts.ExtraConverters)), opts, nil)
+}
+
+// appendConfig implements [AppendConfigWithOptions] using a prebuilt converter
+// table. If report is non-nil, it is filled in while converting.
+func appendConfig(file *builder.File, cfg *otelcol.Config, converterTable map[converterKey]ComponentConverter, opts Options, report *Report) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	groups, err := createPipelineGroups(cfg.Service.Pipelines)
@@ -146,9 +367,8 @@ func AppendConfig(file *builder.File, cfg *otelcol.Config, labelPrefix string, e
 		diags.Add(diag.SeverityLevelCritical, fmt.Sprintf("failed to interpret config: %s", err))
 		return diags
 	}
-	// TODO(rfratto): should this be deduplicated to avoid creating factories
-	// twice?
-	converterTable := buildConverterTable(extraConverters)
+
+	diags.AddAll(validateTelemetry(cfg))
 
 	// Connector components are defined on the top level of the OpenTelemetry
 	// config, but inside of the pipeline definitions they act like regular
@@ -158,7 +378,7 @@ func AppendConfig(file *builder.File, cfg *otelcol.Config, labelPrefix string, e
 	//
 	// Since we want to construct them individually, we'll exclude them from
 	// the list of receivers and exporters manually.
-	connectorIDs := maps.Keys(cfg.Connectors)
+	connectorIDs := orderConnectorIDs(groups, cfg.Connectors)
 
 	// NOTE(rfratto): here, the same component ID will be instantiated once for
 	// every group it's in. This means that converting receivers in multiple
@@ -173,6 +393,20 @@ func AppendConfig(file *builder.File, cfg *otelcol.Config, labelPrefix string, e
 		return diags
 	}
 
+	if usageDiags := validateConnectorUsage(groups, connectorIDs); len(usageDiags) > 0 {
+		diags.AddAll(usageDiags)
+		return diags
+	}
+
+	// A pipeline which eventually exports back into one of its own connectors
+	// would make the generated Alloy components send data in a loop.
+	if cycleDiags := validateNoConnectorCycles(groups, connectorIDs); len(cycleDiags) > 0 {
+		diags.AddAll(cycleDiags)
+		return diags
+	}
+
+	report.addSkipped(cfg)
+
 	// We build the list of extensions 'activated' (defined in the service) as
 	// Alloy components and keep a mapping of their OTel IDs to the blocks we've
 	// built.
@@ -196,7 +430,7 @@ func AppendConfig(file *builder.File, cfg *otelcol.Config, labelPrefix string, e
 
 			componentConfig:      cfg.Extensions,
 			componentID:          cid,
-			componentLabelPrefix: labelPrefix,
+			componentLabelPrefix: opts.LabelPrefix,
 		}
 
 		key := converterKey{Kind: component.KindExtension, Type: ext.Type()}
@@ -205,7 +439,9 @@ func AppendConfig(file *builder.File, cfg *otelcol.Config, labelPrefix string, e
 			panic(fmt.Sprintf("otelcolconvert: no converter found for key %v", key))
 		}
 
+		skipNodes := len(file.Body().Nodes())
 		diags.AddAll(conv.ConvertAndAppend(state, cid, cfg.Extensions[ext]))
+		report.addComponent(cid, file, skipNodes)
 
 		extensionTable[ext] = componentID{
 			Name:  strings.Split(conv.InputComponentName(), "."),
@@ -234,26 +470,41 @@ func AppendConfig(file *builder.File, cfg *otelcol.Config, labelPrefix string, e
 				componentIDPtr := componentstatus.NewInstanceID(id, componentSet.kind)
 				componentID := *componentIDPtr
 
-				state := &State{
-					cfg:   cfg,
-					file:  file,
-					group: &group,
-
-					converterLookup: converterTable,
-					extensionLookup: extensionTable,
-
-					componentConfig:      componentSet.configLookup[id],
-					componentID:          componentID,
-					componentLabelPrefix: labelPrefix,
-				}
-
 				key := converterKey{Kind: componentSet.kind, Type: id.Type()}
 				conv, ok := converterTable[key]
 				if !ok {
 					panic(fmt.Sprintf("otelcolconvert: no converter found for key %v", key))
 				}
 
-				diags.AddAll(conv.ConvertAndAppend(state, componentID, componentSet.configLookup[id]))
+				// The OpenTelemetry Collector creates a processor instance for
+				// every pipeline it's used in. When expanding components, convert
+				// processors once per signal instead of once per group. The zero
+				// signal means the component handles every signal in the group.
+				signals := []pipeline.Signal{{}}
+				if componentSet.kind == component.KindProcessor && opts.expandsProcessor(id) {
+					signals = group.ProcessorSignals(id)
+				}
+
+				for _, signal := range signals {
+					state := &State{
+						cfg:   cfg,
+						file:  file,
+						group: &group,
+
+						converterLookup: converterTable,
+						extensionLookup: extensionTable,
+
+						componentConfig:      componentSet.configLookup[id],
+						componentID:          componentID,
+						componentLabelPrefix: opts.LabelPrefix,
+						componentSignal:      signal,
+						expandProcessor:      opts.expandsProcessor,
+					}
+
+					skipNodes := len(file.Body().Nodes())
+					diags.AddAll(conv.ConvertAndAppend(state, componentID, componentSet.configLookup[id]))
+					report.addComponent(componentID, file, skipNodes)
+				}
 			}
 		}
 	}
@@ -261,6 +512,25 @@ func AppendConfig(file *builder.File, cfg *otelcol.Config, labelPrefix string, e
 	return diags
 }
 
+// validateTelemetry reports the settings of the Collector's own telemetry
+// which can't be converted.
+func validateTelemetry(cfg *otelcol.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The Collector's self-tracing is configured with OpenTelemetry SDK span
+	// processors, which don't map onto Alloy's tracing block.
+	traces := cfg.Service.Telemetry.Traces
+	if len(traces.Processors) > 0 || len(traces.Propagators) > 0 {
+		diags.Add(
+			diag.SeverityLevelWarn,
+			"service::telemetry::traces cannot be converted automatically. "+
+				"Use the tracing block to configure how Alloy exports its own traces.",
+		)
+	}
+
+	return diags
+}
+
 // validateNoDuplicateReceivers validates that a given receiver does not appear
 // in two different pipeline groups. This is required because Alloy does not
 // allow the same receiver to be instantiated more than once, while this is
@@ -286,15 +556,167 @@ func validateNoDuplicateReceivers(groups []pipelineGroup, connectorIDs []compone
 	return diags
 }
 
-func buildConverterTable(extraConverters []ComponentConverter) map[converterKey]ComponentConverter {
+// validatePipelines validates that every pipeline has at least one receiver
+// and at least one exporter. Without both, there's no data flow to convert, so
+// each incomplete pipeline is reported.
+func validatePipelines(cfg *otelcol.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ids := make([]pipeline.ID, 0, len(cfg.Service.Pipelines))
+	for id := range cfg.Service.Pipelines {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b pipeline.ID) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	for _, id := range ids {
+		p := cfg.Service.Pipelines[id]
+		switch {
+		case len(p.Receivers) == 0 && len(p.Exporters) == 0:
+			diags.Add(diag.SeverityLevelCritical, fmt.Sprintf("the pipeline %q has no receivers and no exporters, so there is nothing to convert", id.String()))
+		case len(p.Receivers) == 0:
+			diags.Add(diag.SeverityLevelCritical, fmt.Sprintf("the pipeline %q has no receivers, so no data would flow to its exporters", id.String()))
+		case len(p.Exporters) == 0:
+			diags.Add(diag.SeverityLevelCritical, fmt.Sprintf("the pipeline %q has no exporters, so the data from its receivers would be dropped", id.String()))
+		}
+	}
+
+	return diags
+}
+
+// validateConnectorUsage validates that every connector used in a pipeline is
+// used both as an exporter and as a receiver. A connector which is only used
+// on one side would never receive or never forward any data, and is rejected
+// by the OpenTelemetry Collector.
+func validateConnectorUsage(groups []pipelineGroup, connectorIDs []component.ID) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var asReceiver, asExporter []component.ID
+	for _, group := range groups {
+		asReceiver = mergeIDs(asReceiver, intersectIDs(group.Receivers(), connectorIDs))
+		asExporter = mergeIDs(asExporter, intersectIDs(group.Exporters(), connectorIDs))
+	}
+
+	for _, id := range connectorIDs {
+		usedAsReceiver := slices.Contains(asReceiver, id)
+		usedAsExporter := slices.Contains(asExporter, id)
+
+		switch {
+		case usedAsExporter && !usedAsReceiver:
+			diags.Add(diag.SeverityLevelCritical, fmt.Sprintf(
+				"the connector %q is used as an exporter but not as a receiver; connectors must be used as both",
+				id.String(),
+			))
+		case usedAsReceiver && !usedAsExporter:
+			diags.Add(diag.SeverityLevelCritical, fmt.Sprintf(
+				"the connector %q is used as a receiver but not as an exporter; connectors must be used as both",
+				id.String(),
+			))
+		}
+	}
+
+	return diags
+}
+
+// validateNoConnectorCycles validates that connectors do not form a cycle,
+// where data sent to a connector eventually gets exported back into the same
+// connector. Fanning out from a connector into multiple pipelines is allowed
+// as long as none of them lead back to it.
+//
+// The check is defensive: the built-in connectors all turn traces into
+// metrics, so they can't form a cycle in a valid config. Connectors handled by
+// extra converters, such as a forward connector, could.
+func validateNoConnectorCycles(groups []pipelineGroup, connectorIDs []component.ID) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Build the connector graph, where there's an edge from connector A to
+	// connector B if a pipeline receives from A and exports to B.
+	edges := make(map[component.ID][]component.ID)
+	for _, group := range groups {
+		for _, p := range []*pipelines.PipelineConfig{group.Metrics, group.Logs, group.Traces} {
+			from := intersectIDs(p.Receivers, connectorIDs)
+			to := intersectIDs(p.Exporters, connectorIDs)
+			for _, f := range from {
+				edges[f] = mergeIDs(edges[f], to)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	var (
+		state = make(map[component.ID]int, len(connectorIDs))
+		stack []component.ID
+	)
+
+	var visit func(id component.ID)
+	visit = func(id component.ID) {
+		state[id] = visiting
+		stack = append(stack, id)
+
+		for _, next := range edges[id] {
+			switch state[next] {
+			case unvisited:
+				visit(next)
+			case visiting:
+				// Found a back edge; the cycle is the part of the stack starting at
+				// next.
+				start := slices.Index(stack, next)
+				cycle := make([]string, 0, len(stack)-start+1)
+				for _, c := range stack[start:] {
+					cycle = append(cycle, fmt.Sprintf("%q", c.String()))
+				}
+				cycle = append(cycle, fmt.Sprintf("%q", next.String()))
+
+				diags.Add(diag.SeverityLevelError, fmt.Sprintf(
+					"the configuration contains a cycle between connectors, which would loop data forever: %s",
+					strings.Join(cycle, " -> "),
+				))
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[id] = visited
+	}
+
+	// Visit connectors in a stable order so that the reported cycles are
+	// deterministic.
+	sortedIDs := slices.Clone(connectorIDs)
+	slices.SortFunc(sortedIDs, func(a, b component.ID) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	for _, id := range sortedIDs {
+		if state[id] == unvisited {
+			visit(id)
+		}
+	}
+
+	return diags
+}
+
+// intersectIDs returns the IDs from in which are also in set.
+func intersectIDs(in []component.ID, set []component.ID) []component.ID {
+	var res []component.ID
+	for _, id := range in {
+		if slices.Contains(set, id) {
+			res = append(res, id)
+		}
+	}
+	return res
+}
+
+func buildConverterTable(entries []converterEntry) map[converterKey]ComponentConverter {
 	table := make(map[converterKey]ComponentConverter)
 
 	// Ordering is critical here because conflicting converters are resolved with
-	// the first one in the list winning.
-	allConverters := append(extraConverters, converters...)
-
-	for _, conv := range allConverters {
-		fact := conv.Factory()
+	// the first one in the list winning. newConverterEntries places extra
+	// converters ahead of the built-in ones.
+	for _, entry := range entries {
+		conv, fact := entry.Converter, entry.Factory
 		var kinds []component.Kind
 		switch fact.(type) {
 		case receiver.Factory:
@@ -327,6 +749,35 @@ func buildConverterTable(extraConverters []ComponentConverter) map[converterKey]
 	return table
 }
 
+// orderConnectorIDs returns the IDs of the given connectors in a stable
+// order. Connectors are ordered by their first use in the pipeline groups,
+// followed by any unused connectors ordered by ID.
+func orderConnectorIDs(groups []pipelineGroup, connectors map[component.ID]component.Config) []component.ID {
+	res := make([]component.ID, 0, len(connectors))
+
+	var used []component.ID
+	for _, group := range groups {
+		used = mergeIDs(used, group.Exporters(), group.Receivers())
+	}
+	for _, id := range used {
+		if _, ok := connectors[id]; ok {
+			res = append(res, id)
+		}
+	}
+
+	var unused []component.ID
+	for id := range connectors {
+		if !slices.Contains(res, id) {
+			unused = append(unused, id)
+		}
+	}
+	slices.SortFunc(unused, func(a, b component.ID) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	return append(res, unused...)
+}
+
 func filterIDs(in []component.ID, rem []component.ID) []component.ID {
 	var res []component.ID
 
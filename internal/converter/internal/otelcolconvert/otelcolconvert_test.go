@@ -3,10 +3,25 @@
 package otelcolconvert_test
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 
+	"github.com/grafana/alloy/internal/component/otelcol"
+	"github.com/grafana/alloy/internal/converter/diag"
+	"github.com/grafana/alloy/internal/converter/internal/common"
 	"github.com/grafana/alloy/internal/converter/internal/otelcolconvert"
 	"github.com/grafana/alloy/internal/converter/internal/test_common"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
 )
 
 func TestConvert(t *testing.T) {
@@ -15,8 +30,395 @@ func TestConvert(t *testing.T) {
 	test_common.TestDirectory(t, "testdata/otelcol_without_validation", ".yaml", true, []string{}, otelcolconvert.ConvertWithoutValidation)
 }
 
+// TestConvertDeterministic tests that converting the same config repeatedly
+// produces byte-identical output. Components are stored in maps by the
+// OpenTelemetry Collector, so any iteration over them must be ordered.
+// Expanding components also covers the order of per-signal processors.
+func TestConvertDeterministic(t *testing.T) {
+	inputFiles, err := filepath.Glob(filepath.Join("testdata", "*.yaml"))
+	require.NoError(t, err)
+
+	tt := []struct {
+		name string
+		opts otelcolconvert.Options
+	}{
+		{name: "default", opts: otelcolconvert.Options{}},
+		{name: "expanded", opts: otelcolconvert.Options{ExpandComponents: true}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, f := range inputFiles {
+				t.Run(filepath.Base(f), func(t *testing.T) {
+					in, err := os.ReadFile(f)
+					require.NoError(t, err)
+
+					expected, _, expectedDiags := otelcolconvert.ConvertWithOptions([][]byte{in}, nil, tc.opts)
+					for i := 0; i < 10; i++ {
+						actual, _, actualDiags := otelcolconvert.ConvertWithOptions([][]byte{in}, nil, tc.opts)
+						require.Equal(t, string(expected), string(actual))
+						require.Equal(t, expectedDiags, actualDiags)
+					}
+				})
+			}
+		})
+	}
+}
+
+// TestConvertExpanded tests converting configs with
+// [otelcolconvert.Options.ExpandComponents] set.
+func TestConvertExpanded(t *testing.T) {
+	convertExpanded := func(in []byte, extraArgs []string) ([]byte, diag.Diagnostics) {
+		out, _, diags := otelcolconvert.ConvertWithOptions([][]byte{in}, extraArgs, otelcolconvert.Options{ExpandComponents: true})
+		return out, diags
+	}
+	test_common.TestDirectory(t, "testdata/otelcol_expanded", ".yaml", true, []string{}, convertExpanded)
+
+	convertSharedBatch := func(in []byte, extraArgs []string) ([]byte, diag.Diagnostics) {
+		out, _, diags := otelcolconvert.ConvertWithOptions([][]byte{in}, extraArgs, otelcolconvert.Options{ExpandComponents: true, ShareBatchProcessors: true})
+		return out, diags
+	}
+	test_common.TestDirectory(t, "testdata/otelcol_expanded_shared_batch", ".yaml", true, []string{}, convertSharedBatch)
+}
+
+// TestConvertExpandedGoldens runs the configs in testdata through
+// [otelcolconvert.Options.ExpandComponents]. Configs without processors
+// convert the same way in both modes and are compared against their own
+// golden, while configs with processors are compared against the golden of the
+// same name in testdata/otelcol_expanded_goldens.
+func TestConvertExpandedGoldens(t *testing.T) {
+	inputFiles, err := filepath.Glob(filepath.Join("testdata", "*.yaml"))
+	require.NoError(t, err)
+
+	for _, f := range inputFiles {
+		name := strings.TrimSuffix(filepath.Base(f), ".yaml")
+
+		t.Run(name, func(t *testing.T) {
+			in, err := os.ReadFile(f)
+			require.NoError(t, err)
+
+			actualAlloy, _, actualDiags := otelcolconvert.ConvertWithOptions([][]byte{in}, nil, otelcolconvert.Options{ExpandComponents: true})
+			actualDiags.RemoveDiagsBySeverity(diag.SeverityLevelInfo)
+
+			var actualDiagLines []string
+			for _, d := range actualDiags {
+				actualDiagLines = append(actualDiagLines, d.String())
+			}
+			require.Equal(t, readExpectedLines(t, filepath.Join("testdata", name+".diags")), actualDiagLines)
+
+			expectedFile := filepath.Join("testdata", "otelcol_expanded_goldens", name+".alloy")
+			if _, err := os.Stat(expectedFile); os.IsNotExist(err) {
+				expectedFile = filepath.Join("testdata", name+".alloy")
+			}
+			expectedAlloy, err := os.ReadFile(expectedFile)
+			if os.IsNotExist(err) {
+				// Configs which fail to convert only have expected diags.
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, string(expectedAlloy), string(actualAlloy))
+		})
+	}
+}
+
+// TestConvertExtraArgs tests the flags which can be passed to the converter
+// through extraArgs.
+func TestConvertExtraArgs(t *testing.T) {
+	test_common.TestDirectory(t, "testdata/otelcol_label_prefix", ".yaml", true, []string{"--label-prefix=gateway"}, otelcolconvert.Convert)
+
+	in, err := os.ReadFile("testdata/batch.yaml")
+	require.NoError(t, err)
+
+	t.Run("pretty", func(t *testing.T) {
+		actual, diags := otelcolconvert.Convert(in, []string{"--pretty=false"})
+		diags.RemoveDiagsBySeverity(diag.SeverityLevelInfo)
+		require.Empty(t, diags)
+		require.Contains(t, string(actual), `otelcol.processor.batch "default"`)
+	})
+
+	// The convert command splits "--pretty=false" into a flag and a value.
+	t.Run("pretty with separate value", func(t *testing.T) {
+		expected, _ := otelcolconvert.Convert(in, []string{"--pretty=false"})
+		actual, diags := otelcolconvert.Convert(in, []string{"--pretty", "false"})
+		diags.RemoveDiagsBySeverity(diag.SeverityLevelInfo)
+		require.Empty(t, diags)
+		require.Equal(t, string(expected), string(actual))
+
+		actual, diags = otelcolconvert.Convert(in, []string{"--label-prefix", "gateway", "--pretty", "false"})
+		diags.RemoveDiagsBySeverity(diag.SeverityLevelInfo)
+		require.Empty(t, diags)
+		require.Contains(t, string(actual), `otelcol.processor.batch "gateway_default"`)
+	})
+
+	t.Run("unknown flag", func(t *testing.T) {
+		actual, diags := otelcolconvert.Convert(in, []string{"--label-prefix=gateway", "--unknown"})
+		require.Nil(t, actual)
+		require.Len(t, diags, 1)
+		require.Equal(t, "(Critical) extra arguments are not supported for the otelcol converter: flag provided but not defined: -unknown", diags[0].String())
+	})
+}
+
+// TestConvertWithConverters tests converting configs with extra converters
+// which both override a built-in converter and handle a component which isn't
+// supported out of the box.
+func TestConvertWithConverters(t *testing.T) {
+	extraConverters := []otelcolconvert.ComponentConverter{
+		customProcessorConverter{typ: "batch"},
+		customProcessorConverter{typ: "custom"},
+	}
+
+	// The custom.processor components don't exist in Alloy, so the generated
+	// config can't be loaded.
+	test_common.TestDirectory(t, "testdata/otelcol_extra_converters", ".yaml", false, []string{}, func(in []byte, extraArgs []string) ([]byte, diag.Diagnostics) {
+		out, _, diags := otelcolconvert.ConvertWithOptions([][]byte{in}, extraArgs, otelcolconvert.Options{ExtraConverters: extraConverters})
+		return out, diags
+	})
+}
+
+// customProcessorConverter converts a processor of the given type into a
+// custom.processor.<type> block which only forwards traces.
+type customProcessorConverter struct {
+	typ string
+}
+
+func (c customProcessorConverter) Factory() component.Factory {
+	return processor.NewFactory(component.MustNewType(c.typ), func() component.Config {
+		return &struct{}{}
+	})
+}
+
+func (c customProcessorConverter) InputComponentName() string {
+	return "custom.processor." + c.typ
+}
+
+func (c customProcessorConverter) ConvertAndAppend(state *otelcolconvert.State, id componentstatus.InstanceID, _ component.Config) diag.Diagnostics {
+	args := struct {
+		Output *otelcol.ConsumerArguments `alloy:"output,block"`
+	}{
+		Output: &otelcol.ConsumerArguments{
+			Traces: otelcolconvert.ToTokenizedConsumers(state.Next(id, pipeline.SignalTraces)),
+		},
+	}
+
+	block := common.NewBlockWithOverride([]string{"custom", "processor", c.typ}, state.AlloyComponentLabel(), args)
+	state.Body().AppendBlock(block)
+	return nil
+}
+
+// TestConvertHTTPIncludes tests resolving config fragments referenced through
+// http URIs, which must be explicitly allowed.
+func TestConvertHTTPIncludes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/exporters.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("otlp:\n  endpoint: database:4317\n"))
+	}))
+	defer srv.Close()
+
+	in := []byte(fmt.Sprintf(`
+receivers:
+  otlp:
+    protocols:
+      grpc:
+
+exporters: ${%s/exporters.yaml}
+
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: []
+      exporters: [otlp]
+`, srv.URL))
+
+	t.Run("Allowed", func(t *testing.T) {
+		actual, _, diags := otelcolconvert.ConvertWithOptions([][]byte{in}, nil, otelcolconvert.Options{AllowHTTPIncludes: true})
+		diags.RemoveDiagsBySeverity(diag.SeverityLevelInfo)
+		require.Empty(t, diags)
+		require.Contains(t, string(actual), `endpoint = "database:4317"`)
+	})
+
+	t.Run("AllowedWithFlag", func(t *testing.T) {
+		actual, diags := otelcolconvert.Convert(in, []string{"--allow-http-includes"})
+		diags.RemoveDiagsBySeverity(diag.SeverityLevelInfo)
+		require.Empty(t, diags)
+		require.Contains(t, string(actual), `endpoint = "database:4317"`)
+	})
+
+	t.Run("NotAllowed", func(t *testing.T) {
+		actual, diags := otelcolconvert.Convert(in, nil)
+		require.Nil(t, actual)
+		require.True(t, diags.HasSeverityLevel(diag.SeverityLevelCritical))
+	})
+}
+
 // TestConvertErrors tests errors specifically regarding the reading of
 // OpenTelemetry configurations.
 func TestConvertErrors(t *testing.T) {
 	test_common.TestDirectory(t, "testdata/otelcol_errors", ".yaml", true, []string{}, otelcolconvert.Convert)
 }
+
+// TestConvertMany tests converting multiple input files which get merged
+// together. Each directory in testdata/otelcol_merge is a test case, where the
+// .yaml files are passed in lexical order and the result is compared against
+// expected.alloy and expected.diags.
+func TestConvertMany(t *testing.T) {
+	dirs, err := os.ReadDir("testdata/otelcol_merge")
+	require.NoError(t, err)
+
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+
+		t.Run(dir.Name(), func(t *testing.T) {
+			path := filepath.Join("testdata/otelcol_merge", dir.Name())
+
+			inputFiles, err := filepath.Glob(filepath.Join(path, "*.yaml"))
+			require.NoError(t, err)
+			sort.Strings(inputFiles)
+
+			var inputs [][]byte
+			for _, f := range inputFiles {
+				bb, err := os.ReadFile(f)
+				require.NoError(t, err)
+				inputs = append(inputs, bb)
+			}
+
+			actualAlloy, _, actualDiags := otelcolconvert.ConvertWithOptions(inputs, nil, otelcolconvert.Options{})
+			actualDiags.RemoveDiagsBySeverity(diag.SeverityLevelInfo)
+
+			var actualDiagLines []string
+			for _, d := range actualDiags {
+				actualDiagLines = append(actualDiagLines, d.String())
+			}
+			require.Equal(t, readExpectedLines(t, filepath.Join(path, "expected.diags")), actualDiagLines)
+
+			expectedAlloy, err := os.ReadFile(filepath.Join(path, "expected.alloy"))
+			if os.IsNotExist(err) {
+				require.Empty(t, actualAlloy)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, string(expectedAlloy), string(actualAlloy))
+		})
+	}
+
+	// Options apply to the merged config just like to a single input.
+	t.Run("options", func(t *testing.T) {
+		var inputs [][]byte
+		for _, f := range []string{"1_base.yaml", "2_overlay.yaml"} {
+			bb, err := os.ReadFile(filepath.Join("testdata/otelcol_merge/overrides", f))
+			require.NoError(t, err)
+			inputs = append(inputs, bb)
+		}
+
+		actual, _, diags := otelcolconvert.ConvertWithOptions(inputs, nil, otelcolconvert.Options{LabelPrefix: "gateway", ExpandComponents: true})
+		diags.RemoveDiagsBySeverity(diag.SeverityLevelInfo)
+		require.Empty(t, diags)
+		require.Contains(t, string(actual), `otelcol.processor.batch "gateway_default_traces"`)
+		require.Contains(t, string(actual), `endpoint = "production:4317"`)
+	})
+}
+
+// readExpectedLines returns the non-empty lines of the file at path, or nil if
+// the file doesn't exist.
+func readExpectedLines(t *testing.T, path string) []string {
+	bb, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	require.NoError(t, err)
+
+	var lines []string
+	for _, line := range strings.Split(string(bb), "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// TestConvertWithReport tests the report returned alongside the converted
+// config.
+func TestConvertWithReport(t *testing.T) {
+	in, err := os.ReadFile(filepath.Join("testdata", "oauth2.yaml"))
+	require.NoError(t, err)
+
+	_, report, diags := otelcolconvert.ConvertWithOptions([][]byte{in}, nil, otelcolconvert.Options{})
+	require.False(t, diags.HasSeverityLevel(diag.SeverityLevelCritical))
+	require.NotNil(t, report)
+
+	require.Equal(t, []otelcolconvert.ReportComponent{
+		{Kind: "extension", ID: "oauth2client", Blocks: []string{"otelcol.auth.oauth2.default"}},
+		{Kind: "receiver", ID: "otlp", Blocks: []string{"otelcol.receiver.otlp.default"}},
+		{Kind: "exporter", ID: "otlp/withauth", Blocks: []string{"otelcol.exporter.otlp.default_withauth"}},
+		{Kind: "exporter", ID: "otlphttp/noauth", Blocks: []string{"otelcol.exporter.otlphttp.default_noauth"}},
+	}, report.Components)
+	require.Equal(t, []string{"extension/oauth2client/noop"}, report.Skipped)
+	require.Equal(t, map[string]int{"extension": 1, "receiver": 1, "exporter": 2}, report.CountByKind())
+	require.Equal(t, []string{"otelcol.exporter.otlp.default_withauth"}, report.Labels()["exporter/otlp/withauth"])
+
+	t.Run("InvalidConfig", func(t *testing.T) {
+		_, report, diags := otelcolconvert.ConvertWithOptions([][]byte{[]byte("receivers: [")}, nil, otelcolconvert.Options{})
+		require.True(t, diags.HasSeverityLevel(diag.SeverityLevelCritical))
+		require.Nil(t, report)
+	})
+
+	// Expanded processors are converted once per signal, but only counted
+	// once.
+	t.Run("ExpandedComponents", func(t *testing.T) {
+		in, err := os.ReadFile(filepath.Join("testdata", "processor_chain.yaml"))
+		require.NoError(t, err)
+
+		_, report, diags := otelcolconvert.ConvertWithOptions([][]byte{in}, nil, otelcolconvert.Options{ExpandComponents: true})
+		require.False(t, diags.HasSeverityLevel(diag.SeverityLevelCritical))
+		require.Equal(t, map[string]int{"receiver": 1, "processor": 4, "exporter": 1}, report.CountByKind())
+		require.Equal(t, []string{"otelcol.processor.batch.default_metrics", "otelcol.processor.batch.default_traces"}, report.Labels()["processor/batch"])
+	})
+
+	t.Run("NoBlocks", func(t *testing.T) {
+		in := []byte(`
+receivers:
+  otlp:
+    protocols:
+      grpc:
+
+processors:
+  custom:
+
+exporters:
+  otlp:
+    endpoint: database:4317
+
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [custom]
+      exporters: [otlp]
+`)
+
+		extraConverters := []otelcolconvert.ComponentConverter{
+			emptyProcessorConverter{customProcessorConverter{typ: "custom"}},
+		}
+		_, report, _ := otelcolconvert.ConvertWithOptions([][]byte{in}, nil, otelcolconvert.Options{ExtraConverters: extraConverters})
+		require.NotNil(t, report)
+		require.Equal(t, map[string]int{"receiver": 1, "exporter": 1}, report.CountByKind())
+		require.NotContains(t, report.Labels(), "processor/custom")
+	})
+}
+
+// emptyProcessorConverter fails to convert a processor of the given type
+// without generating any Alloy components.
+type emptyProcessorConverter struct {
+	customProcessorConverter
+}
+
+func (emptyProcessorConverter) ConvertAndAppend(*otelcolconvert.State, componentstatus.InstanceID, component.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags.Add(diag.SeverityLevelError, "failed to convert")
+	return diags
+}
@@ -136,6 +136,24 @@ func (group pipelineGroup) Exporters() []component.ID {
 	)
 }
 
+// ProcessorSignals returns the telemetry signals of the pipelines in the group
+// which use the processor with the given ID.
+func (group pipelineGroup) ProcessorSignals(id component.ID) []pipeline.Signal {
+	var res []pipeline.Signal
+
+	if slices.Contains(group.Metrics.Processors, id) {
+		res = append(res, pipeline.SignalMetrics)
+	}
+	if slices.Contains(group.Logs.Processors, id) {
+		res = append(res, pipeline.SignalLogs)
+	}
+	if slices.Contains(group.Traces.Processors, id) {
+		res = append(res, pipeline.SignalTraces)
+	}
+
+	return res
+}
+
 // mergeIDs merges a set of IDs into a unique list.
 func mergeIDs(in ...[]component.ID) []component.ID {
 	var res []component.ID
@@ -0,0 +1,144 @@
+package otelcolconvert
+
+import (
+	"sort"
+
+	"github.com/grafana/alloy/syntax/token/builder"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/otelcol"
+)
+
+// Report is a structured summary of a conversion, meant for tooling which
+// needs more than the rendered config and the diagnostics.
+type Report struct {
+	// Components holds an entry for every conversion of an OpenTelemetry
+	// Collector component, in the order they were converted. A component used
+	// in multiple pipeline groups is converted, and reported, once per group.
+	// Expanded processors are reported once per signal. Conversions which
+	// didn't generate any Alloy components aren't reported.
+	Components []ReportComponent
+
+	// Skipped holds the OpenTelemetry Collector components which are defined
+	// in the config but not used by the service, and were therefore not
+	// converted. Entries are formatted like "receiver/otlp" and sorted.
+	Skipped []string
+}
+
+// ReportComponent describes a single conversion of an OpenTelemetry Collector
+// component.
+type ReportComponent struct {
+	// Kind is the kind of the component, such as "receiver" or "extension".
+	Kind string
+
+	// ID is the OpenTelemetry Collector ID of the component, such as "otlp/2".
+	ID string
+
+	// Blocks holds the Alloy components generated for it, formatted like
+	// "otelcol.receiver.otlp.default_2".
+	Blocks []string
+}
+
+// CountByKind returns the number of distinct OpenTelemetry Collector
+// components which were converted for every component kind. A component which
+// was converted multiple times, such as once per pipeline group, is only
+// counted once.
+func (r *Report) CountByKind() map[string]int {
+	var (
+		res  = make(map[string]int)
+		seen = make(map[string]struct{}, len(r.Components))
+	)
+	for _, c := range r.Components {
+		key := c.Kind + "/" + c.ID
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		res[c.Kind]++
+	}
+	return res
+}
+
+// Labels returns a mapping of OpenTelemetry Collector component IDs, formatted
+// like "receiver/otlp", to the Alloy components generated for them.
+func (r *Report) Labels() map[string][]string {
+	res := make(map[string][]string, len(r.Components))
+	for _, c := range r.Components {
+		key := c.Kind + "/" + c.ID
+		res[key] = append(res[key], c.Blocks...)
+	}
+	return res
+}
+
+// addComponent records the Alloy blocks which were appended to file since it
+// held skipNodes nodes as the result of converting id. Nothing is recorded if
+// no blocks were appended, such as when the conversion failed.
+func (r *Report) addComponent(id componentstatus.InstanceID, file *builder.File, skipNodes int) {
+	if r == nil {
+		return
+	}
+
+	entry := ReportComponent{
+		Kind: StringifyKind(id.Kind()),
+		ID:   id.ComponentID().String(),
+	}
+	for _, node := range file.Body().Nodes()[skipNodes:] {
+		if block, ok := node.(*builder.Block); ok {
+			entry.Blocks = append(entry.Blocks, StringifyBlock(block))
+		}
+	}
+	if len(entry.Blocks) == 0 {
+		return
+	}
+	r.Components = append(r.Components, entry)
+}
+
+// addSkipped records every component defined in cfg which is not referenced
+// by the service.
+func (r *Report) addSkipped(cfg *otelcol.Config) {
+	if r == nil {
+		return
+	}
+
+	used := make(map[string]struct{})
+	for _, ext := range cfg.Service.Extensions {
+		used[StringifyKind(component.KindExtension)+"/"+ext.String()] = struct{}{}
+	}
+	for _, p := range cfg.Service.Pipelines {
+		for _, id := range p.Receivers {
+			used[StringifyKind(component.KindReceiver)+"/"+id.String()] = struct{}{}
+			used[StringifyKind(component.KindConnector)+"/"+id.String()] = struct{}{}
+		}
+		for _, id := range p.Processors {
+			used[StringifyKind(component.KindProcessor)+"/"+id.String()] = struct{}{}
+		}
+		for _, id := range p.Exporters {
+			used[StringifyKind(component.KindExporter)+"/"+id.String()] = struct{}{}
+			used[StringifyKind(component.KindConnector)+"/"+id.String()] = struct{}{}
+		}
+	}
+
+	addUnused := func(kind component.Kind, ids []component.ID) {
+		for _, id := range ids {
+			key := StringifyKind(kind) + "/" + id.String()
+			if _, ok := used[key]; !ok {
+				r.Skipped = append(r.Skipped, key)
+			}
+		}
+	}
+	addUnused(component.KindReceiver, configIDs(cfg.Receivers))
+	addUnused(component.KindProcessor, configIDs(cfg.Processors))
+	addUnused(component.KindExporter, configIDs(cfg.Exporters))
+	addUnused(component.KindConnector, configIDs(cfg.Connectors))
+	addUnused(component.KindExtension, configIDs(cfg.Extensions))
+
+	sort.Strings(r.Skipped)
+}
+
+func configIDs(configs map[component.ID]component.Config) []component.ID {
+	ids := make([]component.ID, 0, len(configs))
+	for id := range configs {
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -50,7 +50,9 @@ func ConvertWithoutValidation(in []byte, extraArgs []string) ([]byte, diag.Diagn
 		return nil, diags
 	}
 
-	cfg, err := readOpentelemetryConfig(in)
+	entries := newConverterEntries(nil)
+
+	cfg, err := readOpentelemetryConfig(getFactories(entries), Options{}, in)
 	if err != nil {
 		diags.Add(diag.SeverityLevelCritical, err.Error())
 		return nil, diags
@@ -58,7 +60,7 @@ func ConvertWithoutValidation(in []byte, extraArgs []string) ([]byte, diag.Diagn
 
 	f := builder.NewFile()
 
-	diags.AddAll(AppendConfig(f, cfg, "", nil))
+	diags.AddAll(appendConfig(f, cfg, buildConverterTable(entries), Options{}, nil))
 	diags.AddAll(common.ValidateNodes(f))
 
 	var buf bytes.Buffer
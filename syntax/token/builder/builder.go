@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/grafana/alloy/syntax/internal/reflectutil"
@@ -230,10 +231,14 @@ func (b *Body) encodeField(prefix []string, field syntaxtags.Field, fieldValue r
 			inner.body.SetValueOverrideHook(b.valueOverrideHook)
 			b.AppendBlock(inner)
 
-			iter := fieldValue.MapRange()
-			for iter.Next() {
-				mapKey, mapValue := iter.Key(), iter.Value()
-				inner.body.SetAttributeValue(mapKey.String(), mapValue.Interface())
+			// Sort the keys so that the attributes have a deterministic print
+			// order.
+			keys := fieldValue.MapKeys()
+			sort.Slice(keys, func(i, j int) bool {
+				return keys[i].String() < keys[j].String()
+			})
+			for _, mapKey := range keys {
+				inner.body.SetAttributeValue(mapKey.String(), fieldValue.MapIndex(mapKey).Interface())
 			}
 
 		case fieldValue.Kind() == reflect.Slice, fieldValue.Kind() == reflect.Array:
@@ -409,3 +409,30 @@ func TestBuilder_MapBlocks(t *testing.T) {
 
 	require.Equal(t, expect, string(f.Bytes()))
 }
+
+// TestBuilder_MapBlocks_SortKeys ensures that attributes of blocks decoded
+// from Go maps are printed in a deterministic order.
+func TestBuilder_MapBlocks_SortKeys(t *testing.T) {
+	type block struct {
+		Value map[string]any `alloy:"block,block,optional"`
+	}
+
+	f := builder.NewFile()
+	f.Body().AppendFrom(block{
+		Value: map[string]any{
+			"key_a": 1,
+			"key_c": 3,
+			"key_b": 2,
+		},
+	})
+
+	expect := format(t, `
+		block {
+			key_a = 1
+			key_b = 2
+			key_c = 3
+		}
+	`)
+
+	require.Equal(t, expect, string(f.Bytes()))
+}